@@ -0,0 +1,7 @@
+package pkg
+
+type String string
+
+const C = "c"
+
+var V = "v"
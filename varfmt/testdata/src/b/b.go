@@ -0,0 +1,20 @@
+package b
+
+import "a"
+
+var v1 = "v1"
+
+// Wrap forwards to a.Passthrough, a wrapper declared in a different
+// package. It should be recognized as a pass-through via the isPassthrough
+// fact exported when package a was analyzed.
+func Wrap(format string, args ...interface{}) { // want Wrap:"isPassthrough.format=0, args=1."
+	a.Passthrough(format, args...)
+}
+
+func bad() {
+	Wrap(v1) // want "variable `v1` used for Wrap format parameter"
+}
+
+func good() {
+	Wrap("literal %s", v1)
+}
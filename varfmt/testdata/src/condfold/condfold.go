@@ -0,0 +1,25 @@
+package condfold
+
+import "fmt"
+
+// cond exercises SSA phi folding: lv is reassigned along one branch, but
+// every reaching definition is itself a literal constant, so it should be
+// treated the same as a plain const.
+func cond(b bool) {
+	lv := "a"
+	if b {
+		lv = "b"
+	}
+	fmt.Sprintf(lv)
+}
+
+// loop exercises a loop-carried phi: s's reaching definition includes
+// itself (via the BinOp that extends it each iteration), so folding it must
+// not recurse forever chasing its own tail.
+func loop(n int) {
+	s := ""
+	for i := 0; i < n; i++ {
+		s = s + "x"
+	}
+	fmt.Sprintf(s)
+}
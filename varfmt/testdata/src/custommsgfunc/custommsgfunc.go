@@ -0,0 +1,14 @@
+package custommsgfunc
+
+// logMsg stands in for a third-party structured logger not in varfmt's
+// built-in defaultMsgFuncs set; it's only recognized here because the test adds
+// it with -msg-funcs.
+func logMsg(msg string, kv ...interface{}) {}
+
+func bad(msg string) {
+	logMsg(msg) // want "variable `msg` used for logMsg message parameter"
+}
+
+func good() {
+	logMsg("literal")
+}
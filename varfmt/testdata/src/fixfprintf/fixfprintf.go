@@ -0,0 +1,10 @@
+package fixfprintf
+
+import (
+	"fmt"
+	"os"
+)
+
+func bad(v string) {
+	fmt.Fprintf(os.Stdout, v) // want "variable `v` used for fmt.Fprintf format parameter"
+}
@@ -0,0 +1,11 @@
+package fixsprintf
+
+import "fmt"
+
+func bad(v string) string {
+	return fmt.Sprintf(v) // want "variable `v` used for fmt.Sprintf format parameter"
+}
+
+func discard(v string) {
+	fmt.Sprintf(v) // want "variable `v` used for fmt.Sprintf format parameter"
+}
@@ -0,0 +1,16 @@
+package msgfuncs
+
+import "log/slog"
+
+// slog's package-level helpers and *Logger methods are both message-first:
+// the first argument is a human-readable message, not a format string, but
+// an unvetted variable there is just as easy to get wrong by accident.
+func bad(msg string, logger *slog.Logger) {
+	slog.Info(msg)                 // want "variable `msg` used for log/slog.Info message parameter"
+	logger.Warn(msg, "attempt", 3) // want "variable `msg` used for \\(\\*log/slog.Logger\\).Warn message parameter"
+}
+
+func good(logger *slog.Logger) {
+	slog.Info("starting up")
+	logger.Warn("retrying", "attempt", 3)
+}
@@ -0,0 +1,14 @@
+package strict
+
+import "fmt"
+
+// cond is the same shape as condfold.cond, but analyzed with -strict, which
+// disables the SSA-based constant folding and falls back to requiring a
+// literal constant or slice thereof.
+func cond(b bool) {
+	lv := "a"
+	if b {
+		lv = "b"
+	}
+	fmt.Sprintf(lv) // want "variable `lv` used for fmt.Sprintf format parameter"
+}
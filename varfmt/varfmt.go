@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"go/ast"
 	"go/printer"
+	"go/token"
 	"go/types"
+	"sort"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
@@ -29,17 +31,92 @@ throughs in a wrapper of a printf-like function.`
 type varfmtAnalyzer struct {
 	*analysis.Analyzer
 	SuppressNoArgs bool
+	Strict         bool
+	MsgFuncs       funcSet
+}
+
+// isPassthrough is exported as an analysis.Fact on functions whose format
+// parameter is only ever forwarded to the format position of a known
+// printer, or of another isPassthrough function. It lets callers in other
+// packages recognize the wrapper without re-deriving it from source.
+type isPassthrough struct {
+	FormatIndex, ArgsIndex int
+}
+
+func (*isPassthrough) AFact() {}
+
+func (f *isPassthrough) String() string {
+	return fmt.Sprintf("isPassthrough(format=%d, args=%d)", f.FormatIndex, f.ArgsIndex)
+}
+
+// funcSet is a set-of-nonempty-strings-valued flag, keyed by the FullName
+// of the functions it names. It mirrors printf.Analyzer's own "funcs" flag.
+type funcSet map[string]bool
+
+func (fs funcSet) String() string {
+	var list []string
+	for name := range fs {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func (fs funcSet) Set(flag string) error {
+	for _, name := range strings.Split(flag, ",") {
+		if len(name) == 0 {
+			return fmt.Errorf("empty string")
+		}
+		fs[name] = true
+	}
+	return nil
+}
+
+// defaultMsgFuncs records the FullName of functions whose first
+// (non-receiver) argument is a human-readable log message rather than a
+// printf-style format string. Unlike a format string, a message has no
+// verbs to misinterpret, but an unvetted variable is still worth flagging:
+// it's easy to accidentally pass a format string here instead, and
+// structured loggers such as log/slog treat a following odd number of
+// key/value args as a badkey. It seeds each Analyzer's MsgFuncs, which the
+// -msg-funcs flag then adds to.
+var defaultMsgFuncs = funcSet{
+	"log/slog.Debug":                     true,
+	"log/slog.Info":                      true,
+	"log/slog.Warn":                      true,
+	"log/slog.Error":                     true,
+	"(*log/slog.Logger).Debug":           true,
+	"(*log/slog.Logger).Info":            true,
+	"(*log/slog.Logger).Warn":            true,
+	"(*log/slog.Logger).Error":           true,
+	"(*go.uber.org/zap.Logger).Debug":    true,
+	"(*go.uber.org/zap.Logger).Info":     true,
+	"(*go.uber.org/zap.Logger).Warn":     true,
+	"(*go.uber.org/zap.Logger).Error":    true,
+	"(*github.com/rs/zerolog.Event).Msg": true,
+}
+
+// isMsgFunc reports whether tfun is a known message-first logging function.
+func (v *varfmtAnalyzer) isMsgFunc(tfun *types.Func) bool {
+	return v.MsgFuncs[tfun.FullName()]
 }
 
 func Analyzer() *varfmtAnalyzer {
 	v := &varfmtAnalyzer{
 		Analyzer: &analysis.Analyzer{
-			Name:     "varfmt",
-			Doc:      doc,
-			Requires: []*analysis.Analyzer{buildssa.Analyzer, printf.Analyzer},
+			Name:      "varfmt",
+			Doc:       doc,
+			Requires:  []*analysis.Analyzer{buildssa.Analyzer, printf.Analyzer},
+			FactTypes: []analysis.Fact{new(isPassthrough)},
 		},
+		MsgFuncs: make(funcSet, len(defaultMsgFuncs)),
+	}
+	for name := range defaultMsgFuncs {
+		v.MsgFuncs[name] = true
 	}
 	v.Flags.BoolVar(&v.SuppressNoArgs, "no-args", false, "suppress varfmt reports when formatted args are passed")
+	v.Flags.BoolVar(&v.Strict, "strict", false, "require the format argument to be a literal constant (or slice thereof); disable SSA-based constant folding of locals, string concatenation, and conversions")
+	v.Flags.Var(v.MsgFuncs, "msg-funcs", "comma-separated list of message-first log function names to check, in addition to the built-in slog/zap/zerolog set")
 
 	// allow overriding printf flags
 	funcs := printf.Analyzer.Flags.Lookup("funcs")
@@ -60,14 +137,64 @@ func (v *varfmtAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
 	prog := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
 	printers := pass.ResultOf[printf.Analyzer].(*printf.Result)
 
+	fmtParams := map[*ssa.Function]*ssa.Parameter{}
 	for _, fn := range prog.SrcFuncs {
-		if tfun, ok := fn.Object().(*types.Func); ok {
-			var fmtParam *ssa.Parameter
-			if printers.Kind(tfun) != printf.KindNone {
-				if len(fn.Params) >= 2 {
-					fmtParam = fn.Params[len(fn.Params)-2]
+		if _, ok := fn.Object().(*types.Func); !ok {
+			continue
+		}
+		if fmtParam, ok := printfShapedParam(fn); ok {
+			fmtParams[fn] = fmtParam
+		}
+	}
+
+	passthroughs := map[*ssa.Function]isPassthrough{}
+
+	// isKnownPrinter reports whether callee is safe to receive a forwarded
+	// format argument: either printf already classifies it as a printer, or
+	// it (locally or in another package) is a known isPassthrough wrapper.
+	isKnownPrinter := func(callee *ssa.Function) bool {
+		tfun, ok := callee.Object().(*types.Func)
+		if !ok {
+			return false
+		}
+		if printers.Kind(tfun) != printf.KindNone {
+			return true
+		}
+		if _, ok := passthroughs[callee]; ok {
+			return true
+		}
+		var fact isPassthrough
+		return pass.ImportObjectFact(tfun, &fact)
+	}
+
+	// Run to a fixed point: a function is a pass-through once every use of
+	// its format parameter forwards to the format position of a known
+	// printer, including other pass-throughs discovered in this same loop.
+	for changed := true; changed; {
+		changed = false
+		for fn, fmtParam := range fmtParams {
+			if _, ok := passthroughs[fn]; ok {
+				continue
+			}
+			if isFormatPassthrough(fmtParam, isKnownPrinter) {
+				passthroughs[fn] = isPassthrough{
+					FormatIndex: len(fn.Params) - 2,
+					ArgsIndex:   len(fn.Params) - 1,
 				}
+				changed = true
 			}
+		}
+	}
+
+	for fn, pt := range passthroughs {
+		tfun := fn.Object().(*types.Func)
+		pt := pt
+		pass.ExportObjectFact(tfun, &pt)
+	}
+
+	for _, fn := range prog.SrcFuncs {
+		if _, ok := fn.Object().(*types.Func); ok {
+			fmtParam := fmtParams[fn]
 			for _, blk := range fn.Blocks {
 				for _, inst := range blk.Instrs {
 					if call, ok := inst.(ssa.CallInstruction); ok {
@@ -81,54 +208,63 @@ func (v *varfmtAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
 							}
 
 							if tfun, ok := callee.Object().(*types.Func); ok {
-								if printers.Kind(tfun) == printf.KindNone {
-									continue
-								}
-								if len(com.Args) < 2 {
-									continue
+								formatIndex, argsIndex, isPrinter := -1, -1, false
+								if printers.Kind(tfun) != printf.KindNone {
+									formatIndex, argsIndex, isPrinter = len(com.Args)-2, len(com.Args)-1, true
+								} else if pt, ok := passthroughs[callee]; ok {
+									formatIndex, argsIndex, isPrinter = pt.FormatIndex, pt.ArgsIndex, true
+								} else {
+									var calleeFact isPassthrough
+									if pass.ImportObjectFact(tfun, &calleeFact) {
+										formatIndex, argsIndex, isPrinter = calleeFact.FormatIndex, calleeFact.ArgsIndex, true
+									}
 								}
 
-								msg := "non-constant expression"
-
-								fmtarg := com.Args[len(com.Args)-2]
-								switch v := fmtarg.(type) {
-								case *ssa.Const:
-									continue // Constants are fine
-								case *ssa.Slice:
-									if _, ok := v.X.(*ssa.Const); ok {
-										continue // Slices of const strings are ok
+								switch {
+								case isPrinter:
+									if formatIndex < 0 || argsIndex >= len(com.Args) {
+										continue
 									}
-								case *ssa.Parameter:
-									if v == fmtParam {
+
+									fmtarg := com.Args[formatIndex]
+									if p, ok := fmtarg.(*ssa.Parameter); ok && p == fmtParam {
 										continue // pass-through format params are ok.
 									}
-								}
+									if isSafeFormatValue(fmtarg, v.Strict) {
+										continue
+									}
+
+									n, callExpr, parent := argExpr(pass, call, formatIndex, 0)
+									name := calleeName(pass, tfun)
+									pass.Report(analysis.Diagnostic{
+										Pos:            call.Pos(),
+										Message:        fmt.Sprintf("%s used for %s format parameter", describeArg(pp, n), name),
+										SuggestedFixes: v.suggestedFixes(pp, printers.Kind(tfun), name, n, callExpr, parent),
+									})
 
-								var n ast.Node
-								for _, f := range pass.Files {
-									if f.Pos() <= call.Pos() && f.End() >= call.Pos() {
-										path, _ := astutil.PathEnclosingInterval(f, call.Pos(), call.Pos())
-										for _, p := range path {
-											if c, ok := p.(*ast.CallExpr); ok {
-												if len(c.Args) < len(com.Args)-2 {
-													break
-												}
-												n = c.Args[len(com.Args)-2]
-											}
-										}
+								case v.isMsgFunc(tfun):
+									recvOffset := 0
+									if sig, ok := tfun.Type().(*types.Signature); ok && sig.Recv() != nil {
+										recvOffset = 1
+									}
+									if len(com.Args) <= recvOffset {
+										continue
 									}
-								}
 
-								m := fmt.Sprintf("variable `%s`", pp(n))
-								if len(m) < 2*len(msg) {
-									msg = m
-								}
+									msgarg := com.Args[recvOffset]
+									if p, ok := msgarg.(*ssa.Parameter); ok && p == fmtParam {
+										continue // pass-through format params are ok.
+									}
+									if isSafeFormatValue(msgarg, v.Strict) {
+										continue
+									}
 
-								name := tfun.FullName()
-								if tfun.Pkg() == pass.Pkg {
-									name = strings.TrimPrefix(name, pass.Pkg.Name()+".")
+									n, _, _ := argExpr(pass, call, recvOffset, recvOffset)
+									pass.Report(analysis.Diagnostic{
+										Pos:     call.Pos(),
+										Message: fmt.Sprintf("%s used for %s message parameter", describeArg(pp, n), calleeName(pass, tfun)),
+									})
 								}
-								pass.Reportf(call.Pos(), "%s used for %s format parameter", msg, name)
 							}
 						}
 					}
@@ -139,3 +275,242 @@ func (v *varfmtAnalyzer) run(pass *analysis.Pass) (interface{}, error) {
 
 	return nil, nil
 }
+
+// argExpr returns the AST expression for the call argument at ssaIndex (as
+// indexed into call.Common().Args), the enclosing call expression, and that
+// call expression's parent node (nil if not found). recvOffset accounts for
+// static method calls, whose SSA Args include the receiver as element 0
+// even though it doesn't appear in the source Args list.
+func argExpr(pass *analysis.Pass, call ssa.CallInstruction, ssaIndex, recvOffset int) (ast.Node, *ast.CallExpr, ast.Node) {
+	astIndex := ssaIndex - recvOffset
+	var n ast.Node
+	var callExpr *ast.CallExpr
+	var parent ast.Node
+	for _, f := range pass.Files {
+		if f.Pos() <= call.Pos() && f.End() >= call.Pos() {
+			path, _ := astutil.PathEnclosingInterval(f, call.Pos(), call.Pos())
+			for i, p := range path {
+				if c, ok := p.(*ast.CallExpr); ok {
+					if len(c.Args) <= astIndex {
+						break
+					}
+					n = c.Args[astIndex]
+					callExpr = c
+					if i+1 < len(path) {
+						parent = path[i+1]
+					} else {
+						parent = nil
+					}
+					break // path runs innermost-first; the first CallExpr is call's own.
+				}
+			}
+		}
+	}
+	return n, callExpr, parent
+}
+
+// describeArg renders n for use in a diagnostic message, falling back to a
+// generic description when the source text would be unreasonably long, such
+// as a map index or composite literal.
+func describeArg(pp func(ast.Node) string, n ast.Node) string {
+	generic := "non-constant expression"
+	if n == nil {
+		return generic
+	}
+	if m := fmt.Sprintf("variable `%s`", pp(n)); len(m) < 2*len(generic) {
+		return m
+	}
+	return generic
+}
+
+// calleeName returns tfun's name for use in a diagnostic, trimming the
+// current package's own prefix so local calls read as bare identifiers.
+func calleeName(pass *analysis.Pass, tfun *types.Func) string {
+	name := tfun.FullName()
+	if tfun.Pkg() == pass.Pkg {
+		name = strings.TrimPrefix(name, pass.Pkg.Name()+".")
+	}
+	return name
+}
+
+// suggestedFixes builds the automatic rewrites offered for a variable used
+// as a format string. Wrapping it as a %s argument is always safe. Dropping
+// the format call entirely and using the value directly is offered on top
+// of that, but only for fmt.Sprintf(v) with no other args, and only when the
+// call's result is actually consumed (an assignment, return, or argument) —
+// discarding a bare statement's result isn't valid Go, so a result-
+// discarding fmt.Sprintf(v) only gets the %s-wrap fix. printf.KindErrorf is
+// excluded from the drop fix entirely, since collapsing fmt.Errorf(v) to v
+// would turn an error into a plain string.
+func (v *varfmtAnalyzer) suggestedFixes(pp func(ast.Node) string, kind printf.Kind, name string, n ast.Node, call *ast.CallExpr, parent ast.Node) []analysis.SuggestedFix {
+	if n == nil || call == nil {
+		return nil
+	}
+
+	fixes := []analysis.SuggestedFix{{
+		Message: "Wrap as %s argument",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     n.Pos(),
+			End:     n.End(),
+			NewText: []byte(fmt.Sprintf(`"%%s", %s`, pp(n))),
+		}},
+	}}
+
+	if kind == printf.KindErrorf {
+		return fixes
+	}
+
+	if name == "fmt.Sprintf" && len(call.Args) == 1 && resultUsed(parent) {
+		fixes = append(fixes, analysis.SuggestedFix{
+			Message: "Remove fmt.Sprintf and use the value directly",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     call.Pos(),
+				End:     call.End(),
+				NewText: []byte(pp(n)),
+			}},
+		})
+	}
+
+	return fixes
+}
+
+// resultUsed reports whether a call's result is consumed by its parent node
+// rather than discarded. A call that's its own statement, or the operand of
+// a go or defer statement, discards its results; anything else (assignment,
+// return, nested call argument, and so on) consumes them.
+func resultUsed(parent ast.Node) bool {
+	switch parent.(type) {
+	case nil, *ast.ExprStmt, *ast.GoStmt, *ast.DeferStmt:
+		return false
+	default:
+		return true
+	}
+}
+
+// isSafeFormatValue reports whether val is safe to use as a format string:
+// a literal constant, or (unless strict is set) anything that reduces to
+// one by following SSA value flow — a phi of safe values, string
+// concatenation of safe operands, a slice of a safe value, or a conversion
+// of a safe value to a string type. This lets single-assignment locals and
+// simple conditionals be treated the same as a genuine const, mirroring
+// how the upstream printf analyzer folds string addition before checking
+// the format string.
+func isSafeFormatValue(val ssa.Value, strict bool) bool {
+	return isSafeFormatValueSeen(val, strict, map[ssa.Value]bool{})
+}
+
+// isSafeFormatValueSeen does the actual work for isSafeFormatValue, carrying
+// a set of values already visited during this walk. A loop-carried phi
+// (e.g. s = s + "x" inside a loop) is its own ancestor in the SSA value
+// graph, so without this a cyclic value would recurse forever; re-visiting
+// one is treated as safe, the same verdict the rest of the recursion would
+// reach once the cycle is unrolled.
+func isSafeFormatValueSeen(val ssa.Value, strict bool, seen map[ssa.Value]bool) bool {
+	if seen[val] {
+		return true
+	}
+	seen[val] = true
+
+	switch v := val.(type) {
+	case *ssa.Const:
+		return true
+	case *ssa.Slice:
+		if strict {
+			_, ok := v.X.(*ssa.Const)
+			return ok
+		}
+		return isSafeFormatValueSeen(v.X, strict, seen)
+	}
+	if strict {
+		return false
+	}
+	switch v := val.(type) {
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if !isSafeFormatValueSeen(edge, strict, seen) {
+				return false
+			}
+		}
+		return true
+	case *ssa.BinOp:
+		return v.Op == token.ADD && isSafeFormatValueSeen(v.X, strict, seen) && isSafeFormatValueSeen(v.Y, strict, seen)
+	case *ssa.Convert:
+		if basic, ok := v.Type().Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+			return isSafeFormatValueSeen(v.X, strict, seen)
+		}
+	}
+	return false
+}
+
+// printfShapedParam reports whether fn's signature has the classic
+// "format string, args ...interface{}" wrapper shape, returning its format
+// parameter. It intentionally doesn't consult printf.Result, so a function
+// qualifies even when printf's own wrapper heuristic hasn't (yet, or ever)
+// classified it as one — that's what lets a chain of pass-throughs be
+// discovered regardless of which package each link lives in.
+//
+// The trailing parameter need not actually be variadic: a plain
+// []interface{} (or []any) parameter is accepted too, since a caller can
+// forward it with args... just as easily as a genuine variadic parameter,
+// and at the SSA level the two are indistinguishable once spread.
+func printfShapedParam(fn *ssa.Function) (*ssa.Parameter, bool) {
+	n := len(fn.Params)
+	if n < 2 {
+		return nil, false
+	}
+	slice, ok := fn.Params[n-1].Type().Underlying().(*types.Slice)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := slice.Elem().Underlying().(*types.Interface); !ok {
+		return nil, false
+	}
+	basic, ok := fn.Params[n-2].Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsString == 0 {
+		return nil, false
+	}
+	return fn.Params[n-2], true
+}
+
+// isFormatPassthrough reports whether every use of fmtParam is as the
+// format argument of a direct, non-interface call to a function that
+// isKnownPrinter accepts, and every use of fmtParam's function's trailing
+// args parameter is, independently, as the args argument of such a call.
+// A parameter with no uses, or either parameter used any other way, is not
+// considered a pass-through.
+func isFormatPassthrough(fmtParam *ssa.Parameter, isKnownPrinter func(*ssa.Function) bool) bool {
+	fn := fmtParam.Parent()
+	argsParam := fn.Params[len(fn.Params)-1]
+
+	isFormatArg := func(com *ssa.CallCommon) bool { return com.Args[len(com.Args)-2] == fmtParam }
+	isArgsArg := func(com *ssa.CallCommon) bool { return com.Args[len(com.Args)-1] == argsParam }
+
+	return paramReachesOnly(fmtParam, isFormatArg, isKnownPrinter) &&
+		paramReachesOnly(argsParam, isArgsArg, isKnownPrinter)
+}
+
+// paramReachesOnly reports whether every use of param is as a qualifying
+// argument (per matches) of a direct, non-interface call to a function
+// that isKnownPrinter accepts. A parameter with no uses, or one used any
+// other way, doesn't qualify.
+func paramReachesOnly(param *ssa.Parameter, matches func(*ssa.CallCommon) bool, isKnownPrinter func(*ssa.Function) bool) bool {
+	refs := param.Referrers()
+	if refs == nil || len(*refs) == 0 {
+		return false
+	}
+	for _, instr := range *refs {
+		call, ok := instr.(ssa.CallInstruction)
+		if !ok {
+			return false
+		}
+		com := call.Common()
+		if com.IsInvoke() || len(com.Args) < 2 || !matches(com) {
+			return false
+		}
+		callee := com.StaticCallee()
+		if callee == nil || !isKnownPrinter(callee) {
+			return false
+		}
+	}
+	return true
+}
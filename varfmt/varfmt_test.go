@@ -9,5 +9,24 @@ import (
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, varfmt.Analyzer().Analyzer, "a")
+	analysistest.Run(t, testdata, varfmt.Analyzer().Analyzer, "a", "b", "condfold", "msgfuncs")
+}
+
+func TestStrict(t *testing.T) {
+	testdata := analysistest.TestData()
+	a := varfmt.Analyzer()
+	a.Strict = true
+	analysistest.Run(t, testdata, a.Analyzer, "strict")
+}
+
+func TestMsgFuncs(t *testing.T) {
+	testdata := analysistest.TestData()
+	a := varfmt.Analyzer()
+	a.Flags.Set("msg-funcs", "custommsgfunc.logMsg")
+	analysistest.Run(t, testdata, a.Analyzer, "custommsgfunc")
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, varfmt.Analyzer().Analyzer, "fixsprintf", "fixfprintf")
 }